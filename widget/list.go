@@ -2,8 +2,12 @@ package widget
 
 import (
 	"fmt"
+	"image/color"
 	"math"
+	"sort"
 	"sync"
+	"time"
+	"unicode"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -16,9 +20,36 @@ import (
 // ListItemID uniquely identifies an item within a list.
 type ListItemID = int
 
+// SelectionMode represents how many items of a List may be selected at once. Table and Tree
+// do not use it.
+//
+// Since: 2.7
+type SelectionMode int
+
+const (
+	// SelectionSingle allows at most one item to be selected at a time. This is the default.
+	//
+	// Since: 2.7
+	SelectionSingle SelectionMode = iota
+
+	// SelectionMulti allows any number of items to be selected at once, using modifier keys
+	// (Shift to extend a range, Ctrl/Cmd to toggle individual items).
+	//
+	// Since: 2.7
+	SelectionMulti
+
+	// SelectionNone disables selection of items entirely.
+	//
+	// Since: 2.7
+	SelectionNone
+)
+
 // Declare conformity with Widget interface.
 var _ fyne.Widget = (*List)(nil)
 
+// Declare conformity with Focusable interface.
+var _ fyne.Focusable = (*List)(nil)
+
 // List is a widget that pools list items for performance and
 // lays the items out in a vertical direction inside of a scroller.
 // List requires that all items are the same size.
@@ -33,12 +64,209 @@ type List struct {
 	OnSelected   func(id ListItemID)                         `json:"-"`
 	OnUnselected func(id ListItemID)                         `json:"-"`
 
-	scroller      *widget.Scroll
-	selected      []ListItemID
-	itemMin       fyne.Size
-	itemHeights   map[ListItemID]float32
-	offsetY       float32
-	offsetUpdated func(fyne.Position)
+	// Selection controls how many items may be selected at once.
+	//
+	// Since: 2.7
+	Selection SelectionMode
+
+	// OnSelectionChanged is called whenever the set of selected items changes, with the
+	// full, updated selection.
+	//
+	// Since: 2.7
+	OnSelectionChanged func([]ListItemID) `json:"-"`
+
+	// ItemAttributes returns optional metadata for an item: whether it is disabled, a
+	// keyboard Shortcut that activates it while the list is on-screen, and a free-form
+	// Reference retrievable with ItemReference.
+	//
+	// Since: 2.7
+	ItemAttributes func(id ListItemID) ListItemAttrs `json:"-"`
+
+	// AutoHeight measures each row's height from its rendered content, once UpdateItem has
+	// populated the template at the current viewport width, instead of using itemMin.Height
+	// for every row. This is useful for rows containing wrapped labels or other variable-height
+	// content. Use MeasureItem for full control over how a row's height is derived.
+	// While a Filter or search query is active, or when sections are configured, every row is
+	// laid out at itemMin.Height and measured heights are not used.
+	//
+	// Since: 2.7
+	AutoHeight bool
+
+	// MeasureItem overrides how a row's height is computed when AutoHeight is enabled. It
+	// receives the item's template, already updated via UpdateItem, and the current viewport
+	// width, and returns the desired content height. If nil, AutoHeight uses the template's
+	// MinSize().Height.
+	//
+	// Since: 2.7
+	MeasureItem func(id ListItemID, template fyne.CanvasObject, width float32) float32 `json:"-"`
+
+	// SectionCount, SectionLength, CreateHeader and UpdateHeader, when all set, group the list's
+	// rows into sections with a header above each one - for example contacts A-Z, chat days or
+	// settings categories - without composing multiple lists. The header of the section currently
+	// scrolled to the top is pinned there; it switches to the next section once that section's
+	// header reaches the top, and is not pushed out gradually.
+	//
+	// Since: 2.7
+	SectionCount func() int `json:"-"`
+
+	// SectionLength returns the number of rows in the given section. The lengths of every
+	// section must sum to the value returned by Length.
+	//
+	// Since: 2.7
+	SectionLength func(section int) int `json:"-"`
+
+	// CreateHeader returns a new template object for a section header.
+	//
+	// Since: 2.7
+	CreateHeader func() fyne.CanvasObject `json:"-"`
+
+	// UpdateHeader is called to apply the data for the given section to a header template
+	// returned from CreateHeader.
+	//
+	// Since: 2.7
+	UpdateHeader func(section int, header fyne.CanvasObject) `json:"-"`
+
+	// Filter, when set, limits the rows List renders to those for which it returns true.
+	// Selection, ItemReference and keyboard navigation all continue to address rows by their
+	// underlying ListItemID, so changing the filter never loses the current selection. Combine
+	// with SetSearchQuery and MatchItem for a built-in search box, or set Filter directly for
+	// other kinds of filtering.
+	//
+	// Since: 2.7
+	Filter func(id ListItemID) bool `json:"-"`
+
+	// MatchItem reports whether row id matches a search query. It is consulted to build an
+	// implicit Filter once SetSearchQuery has set a non-empty query, and by the list's built-in
+	// typeahead ("type to find") navigation.
+	//
+	// Since: 2.7
+	MatchItem func(id ListItemID, query string) bool `json:"-"`
+
+	scroller          *widget.Scroll
+	stickyHeaderObj   fyne.CanvasObject
+	stickySection     int
+	selected          []ListItemID
+	selectAnchor      ListItemID
+	itemMin           fyne.Size
+	headerMin         fyne.Size
+	itemHeights       map[ListItemID]float32
+	autoHeights       map[ListItemID]float32
+	heightTree        *listHeightIndex
+	heightTreeDefault float32
+	autoWidth         float32
+	offsetY           float32
+	offsetUpdated     func(fyne.Position)
+
+	focused      bool
+	currentIndex ListItemID
+	shortcuts    []fyne.Shortcut
+
+	searchQuery     string
+	filteredIDs     []ListItemID
+	filteredIDIndex map[ListItemID]int
+
+	searchBuffer string
+	searchLast   time.Time
+}
+
+// ListItemAttrs carries optional per-item metadata returned from List.ItemAttributes.
+//
+// Since: 2.7
+type ListItemAttrs struct {
+	// Disabled marks the item as unselectable, non-tappable and skipped during keyboard
+	// navigation.
+	Disabled bool
+
+	// Shortcut, when set, is registered with the list's canvas while the list is visible,
+	// so pressing it selects this item - the "one-key jump" pattern common in list controls.
+	Shortcut fyne.Shortcut
+
+	// Reference stores arbitrary application data for this item. It is retrievable with
+	// List.ItemReference without the caller having to maintain a parallel slice.
+	Reference interface{}
+}
+
+// listHeightIndex is a Fenwick (binary indexed) tree over per-row heights. It gives O(log n)
+// prefix-sum queries and point updates, so scrollTo, MinSize and the visible-row scan stay fast
+// for lists with tens of thousands of rows, rather than the O(n) scan a plain slice would need.
+type listHeightIndex struct {
+	heights []float32 // 0-based, last value set() for each row
+	tree    []float32 // 1-based Fenwick tree over heights
+}
+
+// newListHeightIndex builds an index for n rows, each starting at the given default height.
+func newListHeightIndex(n int, def float32) *listHeightIndex {
+	h := &listHeightIndex{heights: make([]float32, n), tree: make([]float32, n+1)}
+	for i := 0; i < n; i++ {
+		h.set(i, def)
+	}
+	return h
+}
+
+// set updates the height of row i, in O(log n).
+func (h *listHeightIndex) set(i int, height float32) {
+	if i < 0 || i >= len(h.heights) {
+		return
+	}
+	delta := height - h.heights[i]
+	h.heights[i] = height
+	for j := i + 1; j <= len(h.heights); j += j & (-j) {
+		h.tree[j] += delta
+	}
+}
+
+// get returns the last height set for row i.
+func (h *listHeightIndex) get(i int) float32 {
+	if i < 0 || i >= len(h.heights) {
+		return 0
+	}
+	return h.heights[i]
+}
+
+// prefixSum returns the sum of the heights of rows [0, i), in O(log n).
+func (h *listHeightIndex) prefixSum(i int) float32 {
+	if i > len(h.heights) {
+		i = len(h.heights)
+	}
+	sum := float32(0)
+	for j := i; j > 0; j -= j & (-j) {
+		sum += h.tree[j]
+	}
+	return sum
+}
+
+// total returns the sum of every row's height.
+func (h *listHeightIndex) total() float32 {
+	return h.prefixSum(len(h.heights))
+}
+
+// rowAtOffset returns the row whose span, including the padding that follows each row, contains
+// offset, found in O(log n) by binary lifting over the tree rather than scanning every row from
+// the start.
+func (h *listHeightIndex) rowAtOffset(offset, padding float32) int {
+	n := len(h.heights)
+	if n == 0 {
+		return 0
+	}
+
+	pos, remaining := 0, offset
+	topBit := 1
+	for topBit<<1 <= n {
+		topBit <<= 1
+	}
+	for pw := topBit; pw > 0; pw >>= 1 {
+		next := pos + pw
+		// tree[next] covers exactly pw rows here, so pw paddings follow them
+		if next <= n && h.tree[next]+float32(pw)*padding <= remaining {
+			pos = next
+			remaining -= h.tree[next] + float32(pw)*padding
+		}
+	}
+
+	if pos >= n {
+		return n - 1
+	}
+	return pos
 }
 
 // NewList creates and returns a list widget for displaying items in
@@ -46,7 +274,7 @@ type List struct {
 //
 // Since: 1.4
 func NewList(length func() int, createItem func() fyne.CanvasObject, updateItem func(ListItemID, fyne.CanvasObject)) *List {
-	list := &List{BaseWidget: BaseWidget{}, Length: length, CreateItem: createItem, UpdateItem: updateItem}
+	list := &List{BaseWidget: BaseWidget{}, Length: length, CreateItem: createItem, UpdateItem: updateItem, currentIndex: -1}
 	list.ExtendBaseWidget(list)
 	return list
 }
@@ -85,10 +313,74 @@ func (l *List) CreateRenderer() fyne.WidgetRenderer {
 	layout.Layout = newListLayout(l)
 	layout.Resize(layout.MinSize())
 	objects := []fyne.CanvasObject{l.scroller}
+	if l.sectioned() {
+		l.stickyHeaderObj = l.CreateHeader()
+		l.headerMin = l.stickyHeaderObj.MinSize()
+		l.stickyHeaderObj.Hide()
+		objects = append(objects, l.stickyHeaderObj)
+	}
 	lr := newListRenderer(objects, l, l.scroller, layout)
+	l.registerShortcuts()
 	return lr
 }
 
+// Show makes the list visible, registering any per-item Shortcut declared through
+// ItemAttributes with the canvas for as long as the list stays on-screen.
+func (l *List) Show() {
+	l.BaseWidget.Show()
+	l.registerShortcuts()
+}
+
+// Hide makes the list invisible, unregistering any shortcuts registered in Show.
+func (l *List) Hide() {
+	l.unregisterShortcuts()
+	l.BaseWidget.Hide()
+}
+
+// registerShortcuts (re-)registers the Shortcut declared by ItemAttributes for each item with
+// the list's canvas, so pressing it selects the corresponding row.
+func (l *List) registerShortcuts() {
+	if l.ItemAttributes == nil {
+		return
+	}
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(l)
+	if canvas == nil {
+		return
+	}
+	l.unregisterShortcuts()
+
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+	for id := 0; id < length; id++ {
+		shortcut := l.ItemAttributes(id).Shortcut
+		if shortcut == nil {
+			continue
+		}
+
+		row := id
+		canvas.AddShortcut(shortcut, func(fyne.Shortcut) {
+			l.selectWithModifiers(row, 0)
+		})
+		l.shortcuts = append(l.shortcuts, shortcut)
+	}
+}
+
+// unregisterShortcuts removes every shortcut previously registered by registerShortcuts.
+func (l *List) unregisterShortcuts() {
+	if len(l.shortcuts) == 0 {
+		return
+	}
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(l)
+	if canvas != nil {
+		for _, s := range l.shortcuts {
+			canvas.RemoveShortcut(s)
+		}
+	}
+	l.shortcuts = nil
+}
+
 // MinSize returns the size that this widget should not shrink below.
 func (l *List) MinSize() fyne.Size {
 	l.ExtendBaseWidget(l)
@@ -110,6 +402,9 @@ func (l *List) SetItemHeight(id ListItemID, height float32) {
 
 	refresh := l.itemHeights[id] != height
 	l.itemHeights[id] = height
+	if l.heightTree != nil && id >= 0 && id < len(l.heightTree.heights) {
+		l.heightTree.set(id, height)
+	}
 	l.propertyLock.Unlock()
 
 	if refresh {
@@ -117,6 +412,62 @@ func (l *List) SetItemHeight(id ListItemID, height float32) {
 	}
 }
 
+// hasCustomHeights reports whether any row has an explicit (SetItemHeight) or measured
+// (AutoHeight/MeasureItem) height recorded, even one equal to itemMin.Height.
+func (l *List) hasCustomHeights() bool {
+	return len(l.itemHeights) > 0 || len(l.autoHeights) > 0
+}
+
+// ensureHeightTree returns the Fenwick height index for the given row count, rebuilding it from
+// itemHeights/autoHeights only when the row count or the default row height (itemMin.Height) has
+// changed since it was last built; in-place updates from SetItemHeight/setMeasuredHeight keep it
+// in sync the rest of the time.
+func (l *List) ensureHeightTree(length int) *listHeightIndex {
+	if l.heightTree != nil && len(l.heightTree.heights) == length && l.heightTreeDefault == l.itemMin.Height {
+		return l.heightTree
+	}
+
+	tree := newListHeightIndex(length, l.itemMin.Height)
+	for id, height := range l.autoHeights {
+		if id >= 0 && id < length {
+			tree.set(id, height)
+		}
+	}
+	for id, height := range l.itemHeights { // explicit heights take precedence over measured ones
+		if id >= 0 && id < length {
+			tree.set(id, height)
+		}
+	}
+	l.heightTree = tree
+	l.heightTreeDefault = l.itemMin.Height
+	return tree
+}
+
+// setMeasuredHeight records the AutoHeight/MeasureItem-derived height for id, unless an explicit
+// SetItemHeight override exists for that row. It reports whether the effective height changed.
+func (l *List) setMeasuredHeight(id ListItemID, height float32) bool {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+
+	if _, overridden := l.itemHeights[id]; overridden {
+		return false
+	}
+
+	if l.autoHeights == nil {
+		l.autoHeights = make(map[ListItemID]float32)
+	}
+	previous, measured := l.autoHeights[id]
+	if !measured {
+		previous = l.itemMin.Height
+	}
+	changed := previous != height
+	l.autoHeights[id] = height
+	if l.heightTree != nil && id >= 0 && id < len(l.heightTree.heights) {
+		l.heightTree.set(id, height)
+	}
+	return changed
+}
+
 func (l *List) scrollTo(id ListItemID) {
 	if l.scroller == nil {
 		return
@@ -124,25 +475,29 @@ func (l *List) scrollTo(id ListItemID) {
 
 	separatorThickness := theme.Padding()
 	y := float32(0)
-	if l.itemHeights == nil || len(l.itemHeights) == 0 {
-		y = (float32(id) * l.itemMin.Height) + (float32(id) * separatorThickness)
-	} else {
-		for i := 0; i < id; i++ {
-			height := l.itemMin.Height
-			l.propertyLock.RLock()
-			if h, ok := l.itemHeights[i]; ok {
-				height = h
-			}
-			l.propertyLock.RUnlock()
+	itemHeight := l.itemMin.Height
 
-			y += height + separatorThickness
+	l.propertyLock.Lock()
+	if l.hasCustomHeights() && !l.filtered() {
+		length := 0
+		if f := l.Length; f != nil {
+			length = f()
 		}
+		tree := l.ensureHeightTree(length)
+		y = tree.prefixSum(id) + float32(id)*separatorThickness
+		itemHeight = tree.get(id)
+	} else {
+		// A filter compacts positions, so a row's rendered offset is determined by its position
+		// among the visible rows rather than by id directly.
+		pos := l.positionOf(id)
+		y = (float32(pos) * l.itemMin.Height) + (float32(pos) * separatorThickness)
 	}
+	l.propertyLock.Unlock()
 
 	if y < l.scroller.Offset.Y {
 		l.scroller.Offset.Y = y
-	} else if y+l.itemMin.Height > l.scroller.Offset.Y+l.scroller.Size().Height {
-		l.scroller.Offset.Y = y + l.itemMin.Height - l.scroller.Size().Height
+	} else if y+itemHeight > l.scroller.Offset.Y+l.scroller.Size().Height {
+		l.scroller.Offset.Y = y + itemHeight - l.scroller.Size().Height
 	}
 	l.offsetUpdated(l.scroller.Offset)
 }
@@ -154,13 +509,27 @@ func (l *List) Resize(s fyne.Size) {
 		return
 	}
 
+	if l.AutoHeight && s.Width != l.autoWidth {
+		l.propertyLock.Lock()
+		l.autoWidth = s.Width
+		l.autoHeights = nil
+		l.heightTree = nil
+		l.propertyLock.Unlock()
+	}
+
 	l.offsetUpdated(l.scroller.Offset)
 	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).updateList(true)
 }
 
-// Select add the item identified by the given ID to the selection.
+// Select adds the item identified by the given ID to the selection, replacing any existing
+// selection. To select more than one item use SelectRange, SelectAll or, in response to a user
+// modifier-key gesture, the selection is extended automatically.
 func (l *List) Select(id ListItemID) {
-	if len(l.selected) > 0 && id == l.selected[0] {
+	if l.Selection == SelectionNone || l.isDisabled(id) {
+		return
+	}
+	if len(l.selected) == 1 && id == l.selected[0] {
+		l.selectAnchor = id
 		return
 	}
 	length := 0
@@ -170,18 +539,289 @@ func (l *List) Select(id ListItemID) {
 	if id < 0 || id >= length {
 		return
 	}
-	old := l.selected
-	l.selected = []ListItemID{id}
-	defer func() {
-		if f := l.OnUnselected; f != nil && len(old) > 0 {
-			f(old[0])
+
+	l.selectAnchor = id
+	l.setSelection([]ListItemID{id})
+	l.scrollTo(id)
+}
+
+// attrsFor returns the ListItemAttrs declared for id, or the zero value if ItemAttributes is unset.
+func (l *List) attrsFor(id ListItemID) ListItemAttrs {
+	if f := l.ItemAttributes; f != nil {
+		return f(id)
+	}
+	return ListItemAttrs{}
+}
+
+// isDisabled reports whether id is marked Disabled via ItemAttributes.
+func (l *List) isDisabled(id ListItemID) bool {
+	return l.attrsFor(id).Disabled
+}
+
+// ItemReference returns the Reference declared for id via ItemAttributes, or nil if
+// ItemAttributes is unset or declares none for id.
+//
+// Since: 2.7
+func (l *List) ItemReference(id ListItemID) interface{} {
+	return l.attrsFor(id).Reference
+}
+
+// SetSearchQuery sets the text used, together with MatchItem, to build an implicit Filter that
+// hides any row MatchItem reports as not matching query. Pass an empty string to clear it.
+//
+// Since: 2.7
+func (l *List) SetSearchQuery(query string) {
+	l.propertyLock.Lock()
+	l.searchQuery = query
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// filtered reports whether Filter, or an implicit filter built from SetSearchQuery and
+// MatchItem, is currently narrowing the rows List renders.
+func (l *List) filtered() bool {
+	return l.Filter != nil || (l.searchQuery != "" && l.MatchItem != nil)
+}
+
+// passesFilter reports whether row id should be shown under the current Filter/search query.
+func (l *List) passesFilter(id ListItemID) bool {
+	if l.Filter != nil && !l.Filter(id) {
+		return false
+	}
+	if l.searchQuery != "" && l.MatchItem != nil {
+		return l.MatchItem(id, l.searchQuery)
+	}
+	return true
+}
+
+// rebuildFilter recomputes the compact position -> underlying ListItemID mapping used to render
+// only the rows currently passing the filter. It is a no-op, clearing any previous mapping, when
+// no filter is active.
+func (l *List) rebuildFilter(length int) {
+	if !l.filtered() {
+		l.filteredIDs = nil
+		l.filteredIDIndex = nil
+		return
+	}
+
+	ids := make([]ListItemID, 0, length)
+	index := make(map[ListItemID]int, length)
+	for id := 0; id < length; id++ {
+		if l.passesFilter(id) {
+			index[id] = len(ids)
+			ids = append(ids, id)
 		}
-		if f := l.OnSelected; f != nil {
-			f(id)
+	}
+	l.filteredIDs = ids
+	l.filteredIDIndex = index
+}
+
+// visibleLength returns the number of rows rendered out of length once filtering is applied.
+func (l *List) visibleLength(length int) int {
+	if !l.filtered() {
+		return length
+	}
+	return len(l.filteredIDs)
+}
+
+// rowID translates a rendered row position into the underlying ListItemID it represents.
+func (l *List) rowID(pos int) ListItemID {
+	if !l.filtered() || pos < 0 || pos >= len(l.filteredIDs) {
+		return pos
+	}
+	return l.filteredIDs[pos]
+}
+
+// positionOf returns the rendered row position of underlying row id, in O(1) via
+// filteredIDIndex: itself when the list is not filtered, or its index within filteredIDs when
+// it is.
+func (l *List) positionOf(id ListItemID) int {
+	if !l.filtered() {
+		return id
+	}
+	if pos, ok := l.filteredIDIndex[id]; ok {
+		return pos
+	}
+	return id
+}
+
+// sectioned reports whether section grouping is fully configured.
+func (l *List) sectioned() bool {
+	return l.SectionCount != nil && l.SectionLength != nil && l.CreateHeader != nil && l.UpdateHeader != nil
+}
+
+// sectionStart returns the flat row id of the first row in section.
+func (l *List) sectionStart(section int) ListItemID {
+	start := 0
+	for s := 0; s < section; s++ {
+		start += l.SectionLength(s)
+	}
+	return start
+}
+
+// ItemID translates a (section, row) pair into the flat ListItemID used by Select, SelectRange,
+// ItemReference and the other row-addressed APIs. It requires SectionCount, SectionLength,
+// CreateHeader and UpdateHeader to all be set; otherwise it logs an error and returns -1.
+//
+// Since: 2.7
+func (l *List) ItemID(section, row int) ListItemID {
+	if !l.sectioned() {
+		fyne.LogError("ItemID requires SectionCount, SectionLength, CreateHeader and UpdateHeader", nil)
+		return -1
+	}
+	return l.sectionStart(section) + row
+}
+
+// Selected returns the sorted list of item IDs that are currently selected.
+//
+// Since: 2.7
+func (l *List) Selected() []ListItemID {
+	l.propertyLock.RLock()
+	out := make([]ListItemID, len(l.selected))
+	copy(out, l.selected)
+	l.propertyLock.RUnlock()
+
+	sort.Ints(out)
+	return out
+}
+
+// SelectRange selects every item between from and to (inclusive), replacing the current
+// selection. Items marked Disabled via ItemAttributes are skipped. It has no effect unless
+// Selection is SelectionMulti.
+//
+// Since: 2.7
+func (l *List) SelectRange(from, to ListItemID) {
+	if l.Selection != SelectionMulti {
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+
+	ids := make([]ListItemID, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		if id < 0 || id >= length || l.isDisabled(id) {
+			continue
 		}
-	}()
-	l.scrollTo(id)
+		ids = append(ids, id)
+	}
+
+	l.setSelection(ids)
+	l.scrollTo(to)
+}
+
+// SelectAll selects every item in the list except those marked Disabled via ItemAttributes. It
+// has no effect unless Selection is SelectionMulti.
+//
+// Since: 2.7
+func (l *List) SelectAll() {
+	if l.Selection != SelectionMulti {
+		return
+	}
+
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+	if length == 0 {
+		return
+	}
+
+	ids := make([]ListItemID, 0, length)
+	for i := 0; i < length; i++ {
+		if l.isDisabled(i) {
+			continue
+		}
+		ids = append(ids, i)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	l.setSelection(ids)
+}
+
+// toggleSelected flips the membership of id in the current selection, keeping everything else
+// selected. It is used to implement Ctrl/Cmd-click in SelectionMulti mode.
+func (l *List) toggleSelected(id ListItemID) {
+	selected := append([]ListItemID{}, l.selected...)
+	index := -1
+	for i, s := range selected {
+		if s == id {
+			index = i
+			break
+		}
+	}
+
+	if index >= 0 {
+		selected = append(selected[:index], selected[index+1:]...)
+	} else {
+		selected = append(selected, id)
+	}
+
+	l.setSelection(selected)
+}
+
+// selectWithModifiers applies the selection semantics for a tap or key activation of id, taking
+// into account the desktop modifier keys that were held at the time: Shift extends the range from
+// the last plain selection (the anchor), Ctrl/Cmd toggles id within the selection, and no
+// modifier behaves like Select.
+func (l *List) selectWithModifiers(id ListItemID, mod fyne.KeyModifier) {
+	if l.Selection == SelectionNone || l.isDisabled(id) {
+		return
+	}
+
+	if l.Selection == SelectionMulti {
+		switch {
+		case mod&fyne.KeyModifierShift != 0:
+			l.SelectRange(l.selectAnchor, id)
+			return
+		case mod&(fyne.KeyModifierControl|fyne.KeyModifierSuper) != 0:
+			l.toggleSelected(id)
+			return
+		}
+	}
+
+	l.Select(id)
+}
+
+// setSelection replaces the current selection with newSelected, firing OnUnselected/OnSelected
+// for the items that left/joined the set and OnSelectionChanged with the final selection.
+func (l *List) setSelection(newSelected []ListItemID) {
+	old := l.selected
+	oldSet := make(map[ListItemID]bool, len(old))
+	for _, id := range old {
+		oldSet[id] = true
+	}
+	newSet := make(map[ListItemID]bool, len(newSelected))
+	for _, id := range newSelected {
+		newSet[id] = true
+	}
+
+	l.selected = newSelected
 	l.Refresh()
+
+	for _, id := range old {
+		if !newSet[id] {
+			if f := l.OnUnselected; f != nil {
+				f(id)
+			}
+		}
+	}
+	for _, id := range newSelected {
+		if !oldSet[id] {
+			if f := l.OnSelected; f != nil {
+				f(id)
+			}
+		}
+	}
+	if f := l.OnSelectionChanged; f != nil {
+		f(l.Selected())
+	}
 }
 
 // ScrollTo scrolls to the item represented by id
@@ -224,15 +864,20 @@ func (l *List) ScrollToTop() {
 
 // Unselect removes the item identified by the given ID from the selection.
 func (l *List) Unselect(id ListItemID) {
-	if len(l.selected) == 0 || l.selected[0] != id {
+	index := -1
+	for i, s := range l.selected {
+		if s == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
 		return
 	}
 
-	l.selected = nil
-	l.Refresh()
-	if f := l.OnUnselected; f != nil {
-		f(id)
-	}
+	selected := append([]ListItemID{}, l.selected...)
+	selected = append(selected[:index], selected[index+1:]...)
+	l.setSelection(selected)
 }
 
 // UnselectAll removes all items from the selection.
@@ -243,19 +888,188 @@ func (l *List) UnselectAll() {
 		return
 	}
 
-	selected := l.selected
-	l.selected = nil
+	l.setSelection(nil)
+}
+
+// FocusGained is called after this List has gained focus.
+//
+// Implements: fyne.Focusable
+func (l *List) FocusGained() {
+	l.focused = true
+	if l.currentIndex < 0 {
+		length := 0
+		if f := l.Length; f != nil {
+			length = f()
+		}
+		l.setCursor(l.firstEnabled(length))
+		return
+	}
+	l.Refresh()
+}
+
+// FocusLost is called after this List has lost focus.
+//
+// Implements: fyne.Focusable
+func (l *List) FocusLost() {
+	l.focused = false
+	l.Refresh()
+}
+
+// TypedKey is called if a key event happens while this List is focused.
+//
+// Implements: fyne.Focusable
+func (l *List) TypedKey(event *fyne.KeyEvent) {
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+	if length == 0 {
+		return
+	}
+
+	switch event.Name {
+	case fyne.KeyUp:
+		l.moveCursor(-1, length)
+	case fyne.KeyDown:
+		l.moveCursor(1, length)
+	case fyne.KeyHome:
+		l.setCursor(l.firstEnabled(length))
+	case fyne.KeyEnd:
+		l.setCursor(l.lastEnabled(length))
+	case fyne.KeyPageUp:
+		l.moveCursor(-l.visibleRowCount(length), length)
+	case fyne.KeyPageDown:
+		l.moveCursor(l.visibleRowCount(length), length)
+	case fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter:
+		if l.currentIndex >= 0 {
+			l.selectWithModifiers(l.currentIndex, 0)
+		}
+	}
+}
+
+// typeaheadTimeout is how long a gap between keystrokes resets the typeahead search buffer,
+// matching the "type to find" behavior of native list controls.
+const typeaheadTimeout = 750 * time.Millisecond
+
+// TypedRune is called if a text event happens while this List is focused. Printable runes are
+// accumulated into a short-lived search buffer and, via MatchItem, jump-scroll the cursor to the
+// first matching row ("type to find").
+//
+// Implements: fyne.Focusable
+func (l *List) TypedRune(r rune) {
+	if !unicode.IsPrint(r) || l.MatchItem == nil {
+		return
+	}
+
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+	if length == 0 {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(l.searchLast) > typeaheadTimeout {
+		l.searchBuffer = ""
+	}
+	l.searchBuffer += string(r)
+	l.searchLast = now
+
+	for id := 0; id < length; id++ {
+		if !l.navigable(id) {
+			continue
+		}
+		if l.MatchItem(id, l.searchBuffer) {
+			l.setCursor(id)
+			return
+		}
+	}
+}
+
+// setCursor moves the keyboard navigation cursor to id, independent of the current selection,
+// and scrolls it into view.
+func (l *List) setCursor(id ListItemID) {
+	if id == l.currentIndex {
+		return
+	}
+
+	l.currentIndex = id
+	l.scrollTo(id)
 	l.Refresh()
-	if f := l.OnUnselected; f != nil {
-		for _, id := range selected {
-			f(id)
+}
+
+// moveCursor shifts the keyboard navigation cursor by delta rows, skipping over (but not
+// landing on) items marked Disabled via ItemAttributes.
+func (l *List) moveCursor(delta, length int) {
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	remaining := delta
+	if remaining < 0 {
+		remaining = -remaining
+	}
+
+	next, target := l.currentIndex, l.currentIndex
+	for remaining > 0 {
+		candidate := next + step
+		if candidate < 0 || candidate >= length {
+			break
 		}
+		next = candidate
+		if l.navigable(next) {
+			remaining--
+			target = next
+		}
+	}
+	l.setCursor(target)
+}
+
+// navigable reports whether keyboard navigation may land the cursor on id: it must not be
+// Disabled, and must pass the current Filter/search query, if any.
+func (l *List) navigable(id ListItemID) bool {
+	return !l.isDisabled(id) && (!l.filtered() || l.passesFilter(id))
+}
+
+// firstEnabled returns the first item ID, in range [0, length), that is navigable (not Disabled
+// and passing the current filter), or 0 if there is none.
+func (l *List) firstEnabled(length int) ListItemID {
+	for i := 0; i < length; i++ {
+		if l.navigable(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+// lastEnabled returns the last item ID, in range [0, length), that is navigable (not Disabled
+// and passing the current filter), or length-1 if there is none.
+func (l *List) lastEnabled(length int) ListItemID {
+	for i := length - 1; i >= 0; i-- {
+		if l.navigable(i) {
+			return i
+		}
+	}
+	return length - 1
+}
+
+// visibleRowCount returns the number of rows currently fitting in the viewport, used to
+// implement page-up/page-down navigation.
+func (l *List) visibleRowCount(length int) int {
+	if l.scroller == nil {
+		return 1
+	}
+
+	visible, _, _ := l.visibleItemHeights(l.itemMin.Height, length)
+	if len(visible) == 0 {
+		return 1
 	}
+	return len(visible)
 }
 
 func (l *List) visibleItemHeights(itemHeight float32, length int) (visible []float32, offY float32, minRow int) {
 	rowOffset := float32(0)
-	isVisible := false
 	visible = []float32{}
 
 	if l.scroller.Size().Height <= 0 {
@@ -265,7 +1079,10 @@ func (l *List) visibleItemHeights(itemHeight float32, length int) (visible []flo
 	// theme.Padding is a slow call, so we cache it
 	padding := theme.Padding()
 
-	if len(l.itemHeights) == 0 {
+	if !l.hasCustomHeights() || l.filtered() {
+		// A search/Filter changes which row occupies a given position, so the cached per-id
+		// heights (and the Fenwick tree built over them) no longer line up with position; fall
+		// back to the uniform height for the duration of the filter.
 		paddedItemHeight := itemHeight + padding
 
 		offY = float32(math.Floor(float64(l.offsetY/paddedItemHeight))) * paddedItemHeight
@@ -291,27 +1108,21 @@ func (l *List) visibleItemHeights(itemHeight float32, length int) (visible []flo
 		return
 	}
 
-	for i := 0; i < length; i++ {
-		height := itemHeight
-		if h, ok := l.itemHeights[i]; ok {
-			height = h
-		}
+	// Rows have custom heights: find the first visible row in O(log n) using the Fenwick tree,
+	// rather than scanning every row from the start, then collect the (few) visible row heights.
+	tree := l.ensureHeightTree(length)
+	minRow = tree.rowAtOffset(l.offsetY, padding)
+	offY = tree.prefixSum(minRow) + float32(minRow)*padding
+	rowOffset = offY
 
-		if rowOffset <= l.offsetY-height-padding {
-			// before scroll
-		} else if rowOffset <= l.offsetY {
-			minRow = i
-			offY = rowOffset
-			isVisible = true
-		}
+	for i := minRow; i < length; i++ {
 		if rowOffset >= l.offsetY+l.scroller.Size().Height {
 			break
 		}
 
+		height := tree.get(i)
+		visible = append(visible, height)
 		rowOffset += height + padding
-		if isVisible {
-			visible = append(visible, height)
-		}
 	}
 	return
 }
@@ -335,6 +1146,10 @@ func newListRenderer(objects []fyne.CanvasObject, l *List, scroller *widget.Scro
 
 func (l *listRenderer) Layout(size fyne.Size) {
 	l.scroller.Resize(size)
+	if l.list.sectioned() && l.list.stickyHeaderObj != nil {
+		l.list.stickyHeaderObj.Move(fyne.NewPos(0, 0))
+		l.list.stickyHeaderObj.Resize(fyne.NewSize(size.Width, l.list.headerMin.Height))
+	}
 }
 
 func (l *listRenderer) MinSize() fyne.Size {
@@ -345,25 +1160,34 @@ func (l *listRenderer) Refresh() {
 	if f := l.list.CreateItem; f != nil {
 		l.list.itemMin = newListItem(f(), nil).MinSize()
 	}
+	if l.list.sectioned() {
+		l.list.headerMin = l.list.stickyHeaderObj.MinSize()
+	}
 	l.Layout(l.list.Size())
 	l.scroller.Refresh()
 	l.layout.Layout.(*listLayout).updateList(true)
+	l.list.registerShortcuts()
 	canvas.Refresh(l.list.super())
 }
 
 // Declare conformity with interfaces.
-var _ fyne.Focusable = (*listItem)(nil)
 var _ fyne.Widget = (*listItem)(nil)
 var _ fyne.Tappable = (*listItem)(nil)
 var _ desktop.Hoverable = (*listItem)(nil)
+var _ desktop.Mouseable = (*listItem)(nil)
 
 type listItem struct {
 	BaseWidget
 
-	onTapped          func()
+	onTapped          func(fyne.KeyModifier)
 	background        *canvas.Rectangle
+	focusIndicator    *canvas.Rectangle
 	child             fyne.CanvasObject
 	hovered, selected bool
+	cursored          bool
+	disabled          bool
+
+	modifier fyne.KeyModifier
 }
 
 func newListItem(child fyne.CanvasObject, tapped func()) *listItem {
@@ -383,25 +1207,14 @@ func (li *listItem) CreateRenderer() fyne.WidgetRenderer {
 	li.background = canvas.NewRectangle(theme.HoverColor())
 	li.background.Hide()
 
-	objects := []fyne.CanvasObject{li.background, li.child}
+	li.focusIndicator = canvas.NewRectangle(color.Transparent)
+	li.focusIndicator.StrokeColor = theme.FocusColor()
+	li.focusIndicator.StrokeWidth = 2
+	li.focusIndicator.Hide()
 
-	return &listItemRenderer{widget.NewBaseRenderer(objects), li}
-}
-
-// FocusGained is called after this listItem has gained focus.
-//
-// Implements: fyne.Focusable
-func (li *listItem) FocusGained() {
-	li.hovered = true
-	li.Refresh()
-}
+	objects := []fyne.CanvasObject{li.background, li.child, li.focusIndicator}
 
-// FocusLost is called after this listItem has lost focus.
-//
-// Implements: fyne.Focusable
-func (li *listItem) FocusLost() {
-	li.hovered = false
-	li.Refresh()
+	return &listItemRenderer{widget.NewBaseRenderer(objects), li}
 }
 
 // MinSize returns the size that this widget should not shrink below.
@@ -426,36 +1239,33 @@ func (li *listItem) MouseOut() {
 	li.Refresh()
 }
 
-// Tapped is called when a pointer tapped event is captured and triggers any tap handler.
-func (li *listItem) Tapped(*fyne.PointEvent) {
-	if li.onTapped != nil {
-		li.selected = true
-		li.Refresh()
-		li.onTapped()
-	}
+// MouseDown is called when a desktop pointer button is pressed, so the list can learn which
+// modifier keys were held for the gesture that follows.
+//
+// Implements: desktop.Mouseable
+func (li *listItem) MouseDown(e *desktop.MouseEvent) {
+	li.modifier = e.Modifier
 }
 
-// TypedKey is called if a key event happens while this listItem is focused.
+// MouseUp is called when a desktop pointer button is released.
 //
-// Implements: fyne.Focusable
-func (li *listItem) TypedKey(event *fyne.KeyEvent) {
-	switch event.Name {
-	case fyne.KeySpace:
+// Implements: desktop.Mouseable
+func (li *listItem) MouseUp(*desktop.MouseEvent) {
+}
+
+// Tapped is called when a pointer tapped event is captured and triggers any tap handler.
+func (li *listItem) Tapped(*fyne.PointEvent) {
+	if li.disabled {
+		return
+	}
+	if li.onTapped != nil {
 		li.selected = true
 		li.Refresh()
-		if li.onTapped != nil {
-			li.onTapped()
-		}
+		li.onTapped(li.modifier)
+		li.modifier = 0
 	}
 }
 
-// TypedRune is called if a text event happens while this listItem is focused.
-//
-// Implements: fyne.Focusable
-func (li *listItem) TypedRune(_ rune) {
-	// intentionally left blank
-}
-
 // Declare conformity with the WidgetRenderer interface.
 var _ fyne.WidgetRenderer = (*listItemRenderer)(nil)
 
@@ -475,10 +1285,14 @@ func (li *listItemRenderer) MinSize() fyne.Size {
 func (li *listItemRenderer) Layout(size fyne.Size) {
 	li.item.background.Resize(size)
 	li.item.child.Resize(size)
+	li.item.focusIndicator.Resize(size)
 }
 
 func (li *listItemRenderer) Refresh() {
-	if li.item.selected {
+	if li.item.disabled {
+		li.item.background.FillColor = theme.DisabledColor()
+		li.item.background.Show()
+	} else if li.item.selected {
 		li.item.background.FillColor = theme.SelectionColor()
 		li.item.background.Show()
 	} else if li.item.hovered {
@@ -488,6 +1302,14 @@ func (li *listItemRenderer) Refresh() {
 		li.item.background.Hide()
 	}
 	li.item.background.Refresh()
+
+	if li.item.cursored && !li.item.disabled {
+		li.item.focusIndicator.Show()
+	} else {
+		li.item.focusIndicator.Hide()
+	}
+	li.item.focusIndicator.Refresh()
+
 	canvas.Refresh(li.item.super())
 }
 
@@ -502,10 +1324,19 @@ type listLayout struct {
 	itemPool   *syncPool
 	visible    map[ListItemID]*listItem
 	renderLock sync.Mutex
+
+	headerPool     *syncPool
+	visibleHeaders map[int]fyne.CanvasObject
 }
 
 func newListLayout(list *List) fyne.Layout {
-	l := &listLayout{list: list, itemPool: &syncPool{}, visible: make(map[ListItemID]*listItem)}
+	l := &listLayout{
+		list:           list,
+		itemPool:       &syncPool{},
+		visible:        make(map[ListItemID]*listItem),
+		headerPool:     &syncPool{},
+		visibleHeaders: make(map[int]fyne.CanvasObject),
+	}
 	list.offsetUpdated = l.offsetUpdated
 	return l
 }
@@ -524,24 +1355,22 @@ func (l *listLayout) MinSize([]fyne.CanvasObject) fyne.Size {
 		items = f()
 	}
 
+	l.list.rebuildFilter(items)
+	visible := l.list.visibleLength(items)
+
 	separatorThickness := theme.Padding()
-	if l.list.itemHeights == nil || len(l.list.itemHeights) == 0 {
-		return fyne.NewSize(l.list.itemMin.Width,
-			(l.list.itemMin.Height+separatorThickness)*float32(items)-separatorThickness)
+	headerHeight := float32(0)
+	if l.list.sectioned() {
+		headerHeight = l.list.headerMin.Height * float32(l.list.SectionCount())
 	}
 
-	height := float32(0)
-	templateHeight := l.list.itemMin.Height
-	for item := 0; item < items; item++ {
-		itemHeight, ok := l.list.itemHeights[item]
-		if ok {
-			height += itemHeight
-		} else {
-			height += templateHeight
-		}
+	if !l.list.hasCustomHeights() || l.list.filtered() {
+		return fyne.NewSize(l.list.itemMin.Width,
+			(l.list.itemMin.Height+separatorThickness)*float32(visible)-separatorThickness+headerHeight)
 	}
 
-	return fyne.NewSize(l.list.itemMin.Width, height+separatorThickness*float32(items-1))
+	height := l.list.ensureHeightTree(visible).total()
+	return fyne.NewSize(l.list.itemMin.Width, height+separatorThickness*float32(visible-1)+headerHeight)
 }
 
 func (l *listLayout) getItem() *listItem {
@@ -562,8 +1391,10 @@ func (l *listLayout) offsetUpdated(pos fyne.Position) {
 	l.updateList(false)
 }
 
-func (l *listLayout) setupListItem(li *listItem, id ListItemID, focus bool) {
-	previousIndicator := li.selected
+// setupListItem configures li to represent row id and returns true if, as a side effect of
+// AutoHeight measuring the updated template, the row's effective height changed.
+func (l *listLayout) setupListItem(li *listItem, id ListItemID, cursored bool) bool {
+	previousSelected := li.selected
 	li.selected = false
 	for _, s := range l.list.selected {
 		if id == s {
@@ -571,22 +1402,46 @@ func (l *listLayout) setupListItem(li *listItem, id ListItemID, focus bool) {
 			break
 		}
 	}
-	if focus {
-		li.hovered = true
-		li.Refresh()
-	} else if previousIndicator != li.selected || li.hovered {
-		li.hovered = false
+	previousCursored := li.cursored
+	li.cursored = cursored
+	previousDisabled := li.disabled
+	li.disabled = l.list.isDisabled(id)
+	if previousSelected != li.selected || previousCursored != li.cursored || previousDisabled != li.disabled {
 		li.Refresh()
 	}
 	if f := l.list.UpdateItem; f != nil {
 		f(id, li.child)
 	}
-	li.onTapped = func() {
-		l.list.Select(id)
+	if d, ok := li.child.(fyne.Disableable); ok {
+		if li.disabled {
+			d.Disable()
+		} else {
+			d.Enable()
+		}
+	}
+	li.onTapped = func(mod fyne.KeyModifier) {
+		l.list.selectWithModifiers(id, mod)
+		l.list.setCursor(id)
+	}
+
+	heightChanged := false
+	if l.list.AutoHeight {
+		width := l.list.Size().Width
+		height := li.child.MinSize().Height
+		if f := l.list.MeasureItem; f != nil {
+			height = f(id, li.child, width)
+		}
+		heightChanged = l.list.setMeasuredHeight(id, height)
 	}
+	return heightChanged
 }
 
 func (l *listLayout) updateList(refresh bool) {
+	if l.list.sectioned() {
+		l.updateSectionedList()
+		return
+	}
+
 	l.renderLock.Lock()
 	separatorThickness := theme.Padding()
 	width := l.list.Size().Width
@@ -598,12 +1453,17 @@ func (l *listLayout) updateList(refresh bool) {
 		fyne.LogError("Missing UpdateCell callback required for List", nil)
 	}
 
+	l.list.propertyLock.Lock()
+	l.list.rebuildFilter(length)
+	visibleLength := l.list.visibleLength(length)
+	l.list.propertyLock.Unlock()
+
 	wasVisible := l.visible
 
 	l.list.propertyLock.Lock()
-	visibleRowHeights, offY, minRow := l.list.visibleItemHeights(l.list.itemMin.Height, length)
+	visibleRowHeights, offY, minRow := l.list.visibleItemHeights(l.list.itemMin.Height, visibleLength)
 	l.list.propertyLock.Unlock()
-	if len(visibleRowHeights) == 0 && length > 0 { // we can't show anything until we have some dimensions
+	if len(visibleRowHeights) == 0 && visibleLength > 0 { // we can't show anything until we have some dimensions
 		l.renderLock.Unlock() // user code should not be locked
 		return
 	}
@@ -613,7 +1473,7 @@ func (l *listLayout) updateList(refresh bool) {
 
 	y := offY
 	for index, itemHeight := range visibleRowHeights {
-		row := index + minRow
+		row := l.list.rowID(index + minRow)
 		size := fyne.NewSize(width, itemHeight)
 
 		c, ok := wasVisible[row]
@@ -635,16 +1495,8 @@ func (l *listLayout) updateList(refresh bool) {
 
 	l.visible = visible
 
-	var focused fyne.Focusable
-	canvas := fyne.CurrentApp().Driver().CanvasForObject(l.list)
-	if canvas != nil {
-		focused = canvas.Focused()
-	}
 	for id, old := range wasVisible {
 		if _, ok := l.visible[id]; !ok {
-			if focused == old {
-				canvas.Focus(nil)
-			}
 			l.itemPool.Release(old)
 		}
 	}
@@ -657,9 +1509,170 @@ func (l *listLayout) updateList(refresh bool) {
 	l.list.scroller.Content.(*fyne.Container).Objects = objects
 	l.renderLock.Unlock() // user code should not be locked
 
+	heightsChanged := false
 	for row, obj := range visible {
-		l.setupListItem(obj, row, focused == obj)
+		if l.setupListItem(obj, row, l.list.focused && row == l.list.currentIndex) {
+			heightsChanged = true
+		}
+	}
+	if heightsChanged {
+		// Newly measured AutoHeight rows can shift which rows are visible and where; lay out
+		// again now that their heights are cached, rather than waiting for the next refresh.
+		l.updateList(true)
+	}
+}
+
+func (l *listLayout) getHeader() fyne.CanvasObject {
+	obj := l.headerPool.Obtain()
+	if obj == nil {
+		if f := l.list.CreateHeader; f != nil {
+			return f()
+		}
+		return nil
+	}
+	return obj.(fyne.CanvasObject)
+}
+
+// updateSectionedList is the sectioned equivalent of updateList: it interleaves pooled header
+// objects between each section's rows, translating (section, row) pairs to flat ListItemIDs as
+// it walks the list, then hands the header of the topmost visible section to stickyHeaderObj so
+// it stays pinned at y = 0 until the next section's header reaches the top. Like updateList, a
+// Filter/search query is applied by skipping rows (and, when none of a section's rows pass,
+// that section's header too) so filtered-out rows take up no space.
+func (l *listLayout) updateSectionedList() {
+	l.renderLock.Lock()
+	separatorThickness := theme.Padding()
+	width := l.list.Size().Width
+	viewHeight := l.list.scroller.Size().Height
+
+	sectionCount := 0
+	if f := l.list.SectionCount; f != nil {
+		sectionCount = f()
+	}
+	length := 0
+	if f := l.list.Length; f != nil {
+		length = f()
+	}
+
+	l.list.propertyLock.Lock()
+	itemHeight := l.list.itemMin.Height
+	headerHeight := l.list.headerMin.Height
+	offsetY := l.list.offsetY
+	l.list.rebuildFilter(length)
+	filtered := l.list.filtered()
+	l.list.propertyLock.Unlock()
+
+	wasVisible := l.visible
+	wasVisibleHeaders := l.visibleHeaders
+	visible := make(map[ListItemID]*listItem)
+	visibleHeaders := make(map[int]fyne.CanvasObject)
+	cells := make([]fyne.CanvasObject, 0)
+
+	sticky := 0
+	row := ListItemID(0)
+	y := float32(0)
+	for section := 0; section < sectionCount; section++ {
+		sectionLength := l.list.SectionLength(section)
+
+		sectionVisible := !filtered
+		if filtered {
+			for i := 0; i < sectionLength; i++ {
+				if l.list.passesFilter(row + i) {
+					sectionVisible = true
+					break
+				}
+			}
+		}
+		if !sectionVisible {
+			row += sectionLength
+			continue
+		}
+
+		if y <= offsetY {
+			sticky = section
+		}
+		if y+headerHeight >= offsetY && y <= offsetY+viewHeight {
+			h, ok := wasVisibleHeaders[section]
+			if !ok {
+				h = l.getHeader()
+			}
+			if h != nil {
+				h.Move(fyne.NewPos(0, y))
+				h.Resize(fyne.NewSize(width, headerHeight))
+				visibleHeaders[section] = h
+				cells = append(cells, h)
+			}
+		}
+		y += headerHeight
+
+		for i := 0; i < sectionLength; i++ {
+			id := row
+			row++
+			if filtered && !l.list.passesFilter(id) {
+				continue
+			}
+
+			if y+itemHeight >= offsetY && y <= offsetY+viewHeight {
+				c, ok := wasVisible[id]
+				if !ok {
+					c = l.getItem()
+				}
+				if c != nil {
+					size := fyne.NewSize(width, itemHeight)
+					c.Move(fyne.NewPos(0, y))
+					c.Resize(size)
+					visible[id] = c
+					cells = append(cells, c)
+				}
+			}
+			y += itemHeight + separatorThickness
+		}
+	}
+
+	l.visible = visible
+	for id, old := range wasVisible {
+		if _, ok := l.visible[id]; !ok {
+			l.itemPool.Release(old)
+		}
+	}
+	l.visibleHeaders = visibleHeaders
+	for section, old := range wasVisibleHeaders {
+		if _, ok := l.visibleHeaders[section]; !ok {
+			l.headerPool.Release(old)
+		}
+	}
+	l.children = cells
+	l.separators = nil
+
+	l.list.scroller.Content.(*fyne.Container).Objects = append([]fyne.CanvasObject{}, l.children...)
+	l.list.stickySection = sticky
+	l.renderLock.Unlock() // user code should not be locked
+
+	for r, obj := range visible {
+		l.setupListItem(obj, r, l.list.focused && r == l.list.currentIndex)
+	}
+	for section, obj := range visibleHeaders {
+		if f := l.list.UpdateHeader; f != nil {
+			f(section, obj)
+		}
+	}
+
+	l.updateStickyHeader(sticky)
+}
+
+// updateStickyHeader refreshes the list's pinned header to reflect section, the section
+// currently scrolled to the top, and keeps it sized to the list's current width.
+func (l *listLayout) updateStickyHeader(section int) {
+	header := l.list.stickyHeaderObj
+	if header == nil {
+		return
+	}
+	if f := l.list.UpdateHeader; f != nil {
+		f(section, header)
 	}
+	header.Move(fyne.NewPos(0, 0))
+	header.Resize(fyne.NewSize(l.list.Size().Width, l.list.headerMin.Height))
+	header.Show()
 }
 
 func (l *listLayout) updateSeparators() {